@@ -3,9 +3,11 @@ package controller
 import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	informers "k8s.io/client-go/informers/core/v1"
 	kcoreclient "k8s.io/client-go/kubernetes/typed/core/v1"
 	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/openshift/service-ca-operator/pkg/boilerplate/controller"
 	"github.com/openshift/service-ca-operator/pkg/controller/api"
@@ -17,14 +19,29 @@ type configMapCABundleInjectionController struct {
 	configMapClient kcoreclient.ConfigMapsGetter
 	configMapLister listers.ConfigMapLister
 
-	ca string
+	ca       string
+	revision *caRevisionManager
 }
 
-func NewConfigMapCABundleInjectionController(configMaps informers.ConfigMapInformer, configMapsClient kcoreclient.ConfigMapsGetter, ca string) controller.Runner {
+// NewConfigMapCABundleInjectionController returns a controller that injects
+// the service CA trust bundle into annotated ConfigMaps. In addition to the
+// active signing CA it retains a trailingRevisionCount window of prior
+// revisions, recorded as service-ca-<n> ConfigMaps in operatorNamespace, so
+// that consumers with a cached bundle keep trusting certs signed by an
+// outgoing CA until it is rotated out of the window.
+func NewConfigMapCABundleInjectionController(
+	configMaps informers.ConfigMapInformer,
+	configMapsClient kcoreclient.ConfigMapsGetter,
+	operatorNamespace string,
+	trailingRevisionCount int,
+	eventRecorder record.EventRecorder,
+	ca string,
+) controller.Runner {
 	ic := &configMapCABundleInjectionController{
 		configMapClient: configMapsClient,
 		configMapLister: configMaps.Lister(),
 		ca:              ca,
+		revision:        newCARevisionManager(operatorNamespace, trailingRevisionCount, configMapsClient, configMaps.Lister(), eventRecorder),
 	}
 
 	return controller.New("ConfigMapCABundleInjectionController", ic,
@@ -46,21 +63,69 @@ func (ic *configMapCABundleInjectionController) Sync(obj v1.Object) error {
 	if !api.HasInjectCABundleAnnotation(sharedConfigMap) {
 		return nil
 	}
+
+	_, _, bundle, err := ic.LatestRevision()
+	if err != nil {
+		return err
+	}
+
 	// ensure data of configmap
-	return ic.ensureConfigMapCABundleInjection(sharedConfigMap)
+	return ic.ensureConfigMapCABundleInjection(sharedConfigMap, bundle)
+}
+
+// LatestRevision bumps the tracked CA revision when the active signing CA
+// has changed and returns the latest revision number, whether it was just
+// started, and the rendered trust bundle for that revision.
+func (ic *configMapCABundleInjectionController) LatestRevision() (int, bool, string, error) {
+	revision, started, err := ic.revision.ensureRevision(ic.ca)
+	if err != nil {
+		return 0, false, "", err
+	}
+	// ic.ca is always the content of the latest revision's ConfigMap: either
+	// ensureRevision found it unchanged from the existing latest revision, or
+	// it just created the latest revision with exactly this content.
+	bundle, err := ic.revision.renderBundle(revision, ic.ca)
+	if err != nil {
+		return 0, false, "", err
+	}
+	return revision, started, bundle, nil
+}
+
+// RotationStatus reports the latest signing CA revision and whether every
+// annotated ConfigMap across all namespaces has converged to its rendered
+// bundle. It implements operator.RotationStatusSource so the operator can
+// gate Upgradeable=False while a rotation is still propagating.
+func (ic *configMapCABundleInjectionController) RotationStatus() (int, bool, error) {
+	latest, _, bundle, err := ic.LatestRevision()
+	if err != nil {
+		return 0, false, err
+	}
+	configMaps, err := ic.configMapLister.List(labels.Everything())
+	if err != nil {
+		return latest, false, err
+	}
+	for _, cm := range configMaps {
+		if !api.HasInjectCABundleAnnotation(cm) {
+			continue
+		}
+		if cm.Data[api.InjectionDataKey] != bundle {
+			return latest, false, nil
+		}
+	}
+	return latest, true, nil
 }
 
 // ensureConfigMapCABundleInjection will create or update configmap for the
 // CA bundle injection as appropriate.
-func (ic *configMapCABundleInjectionController) ensureConfigMapCABundleInjection(current *corev1.ConfigMap) error {
+func (ic *configMapCABundleInjectionController) ensureConfigMapCABundleInjection(current *corev1.ConfigMap, bundle string) error {
 	// make a copy to avoid mutating cache state
 	configMapCopy := current.DeepCopy()
 	// skip updating when the CA bundle is already there
 	if data, ok := configMapCopy.Data[api.InjectionDataKey]; ok &&
-		data == ic.ca && len(configMapCopy.Data) == 1 {
+		data == bundle && len(configMapCopy.Data) == 1 {
 		return nil
 	}
-	configMapCopy.Data = map[string]string{api.InjectionDataKey: ic.ca}
+	configMapCopy.Data = map[string]string{api.InjectionDataKey: bundle}
 	_, err := ic.configMapClient.ConfigMaps(current.Namespace).Update(configMapCopy)
 	return err
 }
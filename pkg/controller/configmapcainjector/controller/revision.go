@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kcoreclient "k8s.io/client-go/kubernetes/typed/core/v1"
+	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/openshift/service-ca-operator/pkg/controller/api"
+)
+
+const (
+	// caRevisionConfigMapPrefix names the numbered ConfigMaps (service-ca-<n>)
+	// that preserve prior signing CA revisions in the operator namespace.
+	caRevisionConfigMapPrefix = "service-ca-"
+
+	// defaultTrailingRevisionCount is the number of prior CA revisions kept in
+	// the rendered trust bundle in addition to the current one.
+	defaultTrailingRevisionCount = 1
+)
+
+// caRevisionManager tracks the history of signing CA revisions, modeled on
+// library-go's revision controller: the currently active signing CA is
+// stored, by content, as a numbered service-ca-<n> ConfigMap in the operator
+// namespace, and a trailing window of prior revisions is retained so that
+// consumers with a cached trust bundle keep trusting certs signed by an
+// outgoing CA until it falls out of that window.
+type caRevisionManager struct {
+	operatorNamespace     string
+	trailingRevisionCount int
+
+	configMapClient kcoreclient.ConfigMapsGetter
+	configMapLister listers.ConfigMapLister
+
+	eventRecorder record.EventRecorder
+}
+
+func newCARevisionManager(operatorNamespace string, trailingRevisionCount int, configMapClient kcoreclient.ConfigMapsGetter, configMapLister listers.ConfigMapLister, eventRecorder record.EventRecorder) *caRevisionManager {
+	if trailingRevisionCount < 0 {
+		trailingRevisionCount = defaultTrailingRevisionCount
+	}
+	return &caRevisionManager{
+		operatorNamespace:     operatorNamespace,
+		trailingRevisionCount: trailingRevisionCount,
+		configMapClient:       configMapClient,
+		configMapLister:       configMapLister,
+		eventRecorder:         eventRecorder,
+	}
+}
+
+func revisionConfigMapName(revision int) string {
+	return fmt.Sprintf("%s%d", caRevisionConfigMapPrefix, revision)
+}
+
+// existingRevisions returns the revisions currently recorded in the operator
+// namespace, sorted newest first. It lists via the live client rather than
+// the shared informer lister: ensureRevision creates new service-ca-<n>
+// ConfigMaps through the live client too, and Sync runs once per annotated
+// object, so a lister read here could still be showing the pre-Create state
+// when the very next Sync call asks "does a revision already exist for this
+// CA". That lag would make an unchanged CA look unrecorded and mint a
+// spurious extra revision. A live read is always consistent with what this
+// manager itself just wrote.
+func (m *caRevisionManager) existingRevisions() ([]int, error) {
+	list, err := m.configMapClient.ConfigMaps(m.operatorNamespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	revisions := []int{}
+	for _, cm := range list.Items {
+		if !strings.HasPrefix(cm.Name, caRevisionConfigMapPrefix) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(cm.Name, caRevisionConfigMapPrefix))
+		if err != nil {
+			continue
+		}
+		revisions = append(revisions, n)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(revisions)))
+	return revisions, nil
+}
+
+// ensureRevision compares currentCA against the bytes of the latest recorded
+// revision and, only when they differ, creates a new service-ca-<n>
+// ConfigMap to track it. It returns the (possibly unchanged) latest revision
+// number and whether a new revision was started.
+func (m *caRevisionManager) ensureRevision(currentCA string) (int, bool, error) {
+	revisions, err := m.existingRevisions()
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(revisions) > 0 {
+		latest := revisions[0]
+		existing, err := m.configMapClient.ConfigMaps(m.operatorNamespace).Get(revisionConfigMapName(latest), metav1.GetOptions{})
+		if err != nil {
+			return 0, false, err
+		}
+		if existing.Data[api.InjectionDataKey] == currentCA {
+			return latest, false, nil
+		}
+		m.eventRecorder.Eventf(existing, corev1.EventTypeNormal, "RevisionTriggered", "new signing CA observed, triggering revision %d", latest+1)
+	}
+
+	next := 0
+	if len(revisions) > 0 {
+		next = revisions[0] + 1
+	}
+	newRevision := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      revisionConfigMapName(next),
+			Namespace: m.operatorNamespace,
+		},
+		Data: map[string]string{api.InjectionDataKey: currentCA},
+	}
+	created, err := m.configMapClient.ConfigMaps(m.operatorNamespace).Create(newRevision)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return 0, false, err
+	}
+	if created == nil {
+		created = newRevision
+	}
+	m.eventRecorder.Eventf(created, corev1.EventTypeNormal, "StartingNewRevision", "starting new signing CA revision %d", next)
+
+	if err := m.pruneOldRevisions(append([]int{next}, revisions...)); err != nil {
+		return next, true, err
+	}
+
+	return next, true, nil
+}
+
+// renderBundle builds a PEM bundle containing the signing CA for the given
+// revision plus the trailing window of prior revisions, newest first, so
+// that callers retain trust in outgoing CAs until the window lapses.
+// latestContent is the already-known content of the latest revision's
+// ConfigMap; it is used in place of a lister lookup for that entry because,
+// on the sync that starts a new revision, the lister cache has not yet
+// observed the ConfigMap this same reconcile just created through the live
+// client.
+func (m *caRevisionManager) renderBundle(latest int, latestContent string) (string, error) {
+	bundle := &strings.Builder{}
+	for i := 0; i <= m.trailingRevisionCount; i++ {
+		revision := latest - i
+		if revision < 0 {
+			break
+		}
+		var ca string
+		if i == 0 {
+			ca = latestContent
+		} else {
+			cm, err := m.configMapLister.ConfigMaps(m.operatorNamespace).Get(revisionConfigMapName(revision))
+			if err != nil {
+				if apierrors.IsNotFound(err) {
+					break
+				}
+				return "", err
+			}
+			var ok bool
+			ca, ok = cm.Data[api.InjectionDataKey]
+			if !ok {
+				continue
+			}
+		}
+		if bundle.Len() > 0 {
+			bundle.WriteString("\n")
+		}
+		bundle.WriteString(strings.TrimSpace(ca))
+		bundle.WriteString("\n")
+	}
+	return bundle.String(), nil
+}
+
+// pruneOldRevisions deletes revisions that have fallen outside the trailing
+// window so the operator namespace doesn't accumulate stale signing CAs
+// forever.
+func (m *caRevisionManager) pruneOldRevisions(revisions []int) error {
+	if len(revisions) <= m.trailingRevisionCount+1 {
+		return nil
+	}
+	for _, revision := range revisions[m.trailingRevisionCount+1:] {
+		name := revisionConfigMapName(revision)
+		err := m.configMapClient.ConfigMaps(m.operatorNamespace).Delete(name, nil)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
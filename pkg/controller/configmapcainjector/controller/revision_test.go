@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
+
+	"github.com/openshift/service-ca-operator/pkg/controller/api"
+)
+
+// newTestRevisionManager returns a caRevisionManager whose live client is
+// backed by fakeClient but whose lister is never synced, modeling the
+// window where the shared informer cache hasn't yet observed a ConfigMap
+// this same manager just created through the live client.
+func newTestRevisionManager(fakeClient *fake.Clientset) *caRevisionManager {
+	lister := informers.NewSharedInformerFactory(fakeClient, 0).Core().V1().ConfigMaps().Lister()
+	return newCARevisionManager("operator-namespace", 1, fakeClient.CoreV1(), lister, record.NewFakeRecorder(10))
+}
+
+func TestEnsureRevisionUnchangedCADoesNotLagBehindLister(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset()
+	m := newTestRevisionManager(fakeClient)
+
+	revision, started, err := m.ensureRevision("ca-v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if revision != 0 || !started {
+		t.Fatalf("expected revision 0 to be started, got revision=%d started=%v", revision, started)
+	}
+
+	// The lister backing m was never synced, so it still reports zero
+	// ConfigMaps even though service-ca-0 now exists via the live client.
+	// ensureRevision must not rely on it: with the same unchanged CA, it
+	// should find the live revision 0, compare against it, and report no
+	// new revision started.
+	revision, started, err = m.ensureRevision("ca-v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if revision != 0 {
+		t.Errorf("expected revision to remain 0, got %d", revision)
+	}
+	if started {
+		t.Errorf("expected no new revision for an unchanged CA, but one was started")
+	}
+
+	list, err := fakeClient.CoreV1().ConfigMaps("operator-namespace").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Items) != 1 {
+		t.Errorf("expected exactly one revision ConfigMap, got %d", len(list.Items))
+	}
+}
+
+func TestEnsureRevisionChangedCAStartsNewRevision(t *testing.T) {
+	fakeClient := fake.NewSimpleClientset()
+	m := newTestRevisionManager(fakeClient)
+
+	if _, _, err := m.ensureRevision("ca-v1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	revision, started, err := m.ensureRevision("ca-v2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if revision != 1 || !started {
+		t.Fatalf("expected revision 1 to be started, got revision=%d started=%v", revision, started)
+	}
+
+	cm, err := fakeClient.CoreV1().ConfigMaps("operator-namespace").Get("service-ca-1", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cm.Data[api.InjectionDataKey] != "ca-v2" {
+		t.Errorf("expected revision 1 to contain %q, got %q", "ca-v2", cm.Data[api.InjectionDataKey])
+	}
+}
@@ -0,0 +1,39 @@
+package injection
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+	apiregistrationclient "k8s.io/kube-aggregator/pkg/client/clientset_generated/clientset/typed/apiregistration/v1"
+
+	"github.com/openshift/service-ca-operator/pkg/controller/api"
+)
+
+// apiServiceInjector injects the service CA bundle into spec.caBundle of an
+// annotated APIService so aggregated API servers trust the front proxy.
+type apiServiceInjector struct {
+	client apiregistrationclient.APIServicesGetter
+}
+
+func NewAPIServiceInjector(client apiregistrationclient.APIServicesGetter) Injector {
+	return &apiServiceInjector{client: client}
+}
+
+func (i *apiServiceInjector) ShouldInject(obj v1.Object) bool {
+	return api.HasInjectCABundleAnnotation(obj)
+}
+
+func (i *apiServiceInjector) Inject(obj v1.Object, caPEM string) bool {
+	apiService := obj.(*apiregistrationv1.APIService)
+	if string(apiService.Spec.CABundle) == caPEM {
+		return false
+	}
+	apiService.Spec.CABundle = []byte(caPEM)
+	return true
+}
+
+func (i *apiServiceInjector) Update(ctx context.Context, obj v1.Object) (v1.Object, error) {
+	apiService := obj.(*apiregistrationv1.APIService)
+	return i.client.APIServices().Update(apiService)
+}
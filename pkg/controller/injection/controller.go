@@ -0,0 +1,76 @@
+package injection
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openshift/service-ca-operator/pkg/boilerplate/controller"
+	"github.com/openshift/service-ca-operator/pkg/controller/api"
+)
+
+// CABundleFunc returns the trust bundle that should currently be injected.
+// It is provided by the signer/rotation layer so that every Injector kind
+// observes the same, possibly multi-revision, PEM bundle.
+type CABundleFunc func() (string, error)
+
+// injectionController drives a single Injector against its own informer. It
+// is generic over resource kind: ConfigMaps, Secrets, webhook
+// configurations, APIServices, and CRDs all share this Sync loop and differ
+// only in their Injector implementation.
+type injectionController struct {
+	name     string
+	injector Injector
+	getter   func(namespace, name string) (v1.Object, error)
+	caBundle CABundleFunc
+}
+
+// New wires up an Injector against informer, filtering events with the same
+// service.beta.openshift.io/inject-cabundle annotation the ConfigMap
+// injector uses, and returns a controller.Runner ready to be started by
+// controller.New's caller.
+func New(name string, injector Injector, getter func(namespace, name string) (v1.Object, error), caBundle CABundleFunc, informer cache.SharedIndexInformer) controller.Runner {
+	ic := &injectionController{
+		name:     name,
+		injector: injector,
+		getter:   getter,
+		caBundle: caBundle,
+	}
+
+	return controller.New(name, ic,
+		controller.WithInformer(informer, controller.FilterFuncs{
+			AddFunc:    api.HasInjectCABundleAnnotation,
+			UpdateFunc: api.HasInjectCABundleAnnotationUpdate,
+		}),
+	)
+}
+
+func (ic *injectionController) Key(namespace, name string) (v1.Object, error) {
+	return ic.getter(namespace, name)
+}
+
+func (ic *injectionController) Sync(obj v1.Object) error {
+	if !ic.injector.ShouldInject(obj) {
+		return nil
+	}
+
+	caPEM, err := ic.caBundle()
+	if err != nil {
+		return err
+	}
+
+	// Inject mutates obj in place, so work on a copy to avoid mutating
+	// cache state.
+	if ro, ok := obj.(runtime.Object); ok {
+		obj = ro.DeepCopyObject().(v1.Object)
+	}
+
+	if !ic.injector.Inject(obj, caPEM) {
+		return nil
+	}
+
+	_, err = ic.injector.Update(context.TODO(), obj)
+	return err
+}
@@ -0,0 +1,46 @@
+package injection
+
+import (
+	"context"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiextensionsclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/typed/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/service-ca-operator/pkg/controller/api"
+)
+
+// crdConversionInjector injects the service CA bundle into an annotated
+// CustomResourceDefinition's spec.conversion.webhook.clientConfig.caBundle,
+// covering CRDs whose conversion webhook is served by an in-cluster
+// service.
+type crdConversionInjector struct {
+	client apiextensionsclient.CustomResourceDefinitionsGetter
+}
+
+func NewCRDConversionInjector(client apiextensionsclient.CustomResourceDefinitionsGetter) Injector {
+	return &crdConversionInjector{client: client}
+}
+
+func (i *crdConversionInjector) ShouldInject(obj v1.Object) bool {
+	crd := obj.(*apiextensionsv1.CustomResourceDefinition)
+	return api.HasInjectCABundleAnnotation(obj) &&
+		crd.Spec.Conversion != nil &&
+		crd.Spec.Conversion.Webhook != nil &&
+		crd.Spec.Conversion.Webhook.ClientConfig != nil
+}
+
+func (i *crdConversionInjector) Inject(obj v1.Object, caPEM string) bool {
+	crd := obj.(*apiextensionsv1.CustomResourceDefinition)
+	clientConfig := crd.Spec.Conversion.Webhook.ClientConfig
+	if string(clientConfig.CABundle) == caPEM {
+		return false
+	}
+	clientConfig.CABundle = []byte(caPEM)
+	return true
+}
+
+func (i *crdConversionInjector) Update(ctx context.Context, obj v1.Object) (v1.Object, error) {
+	crd := obj.(*apiextensionsv1.CustomResourceDefinition)
+	return i.client.CustomResourceDefinitions().Update(crd)
+}
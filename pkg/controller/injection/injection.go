@@ -0,0 +1,29 @@
+// Package injection factors CA bundle injection into a single generic
+// controller that can drive any resource kind which exposes a caBundle-like
+// field: webhook clientConfigs, APIService specs, CRD conversion webhooks,
+// and TLS Secrets, in addition to the ConfigMaps handled by
+// pkg/controller/configmapcainjector. All of them are keyed off the same
+// "service.beta.openshift.io/inject-cabundle" annotation.
+package injection
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Injector knows how to recognize and mutate a single resource kind so that
+// it carries the current service CA bundle.
+type Injector interface {
+	// ShouldInject reports whether obj is annotated for CA bundle injection
+	// and, for kinds whose caBundle field is optional, whether obj has it.
+	ShouldInject(obj v1.Object) bool
+
+	// Inject writes caPEM into obj's caBundle field(s) and reports whether
+	// obj was changed. obj is mutated in place; callers are expected to pass
+	// a copy obtained from the lister.
+	Inject(obj v1.Object, caPEM string) (mutated bool)
+
+	// Update persists obj, which has already been mutated by Inject.
+	Update(ctx context.Context, obj v1.Object) (v1.Object, error)
+}
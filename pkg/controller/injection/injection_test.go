@@ -0,0 +1,205 @@
+package injection
+
+import (
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apiregistrationv1 "k8s.io/kube-aggregator/pkg/apis/apiregistration/v1"
+)
+
+const testCAPEM = "-----BEGIN CERTIFICATE-----\ntest\n-----END CERTIFICATE-----\n"
+
+func TestSecretInjectorInject(t *testing.T) {
+	tests := []struct {
+		name         string
+		secret       *corev1.Secret
+		expectMutate bool
+	}{
+		{
+			name:         "no-op when bundle already matches",
+			secret:       &corev1.Secret{Data: map[string][]byte{"service-ca.crt": []byte(testCAPEM)}},
+			expectMutate: false,
+		},
+		{
+			name:         "mutates when bundle differs",
+			secret:       &corev1.Secret{Data: map[string][]byte{"service-ca.crt": []byte("stale")}},
+			expectMutate: true,
+		},
+		{
+			name:         "mutates when bundle key is absent",
+			secret:       &corev1.Secret{},
+			expectMutate: true,
+		},
+	}
+
+	i := &secretInjector{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mutated := i.Inject(test.secret, testCAPEM)
+			if mutated != test.expectMutate {
+				t.Errorf("expected mutated=%v, got %v", test.expectMutate, mutated)
+			}
+			if string(test.secret.Data["service-ca.crt"]) != testCAPEM {
+				t.Errorf("expected bundle to be injected, got %q", test.secret.Data["service-ca.crt"])
+			}
+		})
+	}
+}
+
+func TestSecretInjectorPreservesOtherKeys(t *testing.T) {
+	secret := &corev1.Secret{
+		Data: map[string][]byte{
+			"tls.crt": []byte("cert"),
+			"tls.key": []byte("key"),
+		},
+	}
+	i := &secretInjector{}
+
+	if !i.Inject(secret, testCAPEM) {
+		t.Fatalf("expected Inject to report a mutation")
+	}
+	if string(secret.Data["tls.crt"]) != "cert" {
+		t.Errorf("expected tls.crt to survive injection, got %q", secret.Data["tls.crt"])
+	}
+	if string(secret.Data["tls.key"]) != "key" {
+		t.Errorf("expected tls.key to survive injection, got %q", secret.Data["tls.key"])
+	}
+	if string(secret.Data["service-ca.crt"]) != testCAPEM {
+		t.Errorf("expected service-ca.crt to be injected, got %q", secret.Data["service-ca.crt"])
+	}
+}
+
+func TestAPIServiceInjectorInject(t *testing.T) {
+	tests := []struct {
+		name         string
+		apiService   *apiregistrationv1.APIService
+		expectMutate bool
+	}{
+		{
+			name:         "no-op when bundle already matches",
+			apiService:   &apiregistrationv1.APIService{Spec: apiregistrationv1.APIServiceSpec{CABundle: []byte(testCAPEM)}},
+			expectMutate: false,
+		},
+		{
+			name:         "mutates when bundle differs",
+			apiService:   &apiregistrationv1.APIService{Spec: apiregistrationv1.APIServiceSpec{CABundle: []byte("stale")}},
+			expectMutate: true,
+		},
+	}
+
+	i := &apiServiceInjector{}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mutated := i.Inject(test.apiService, testCAPEM)
+			if mutated != test.expectMutate {
+				t.Errorf("expected mutated=%v, got %v", test.expectMutate, mutated)
+			}
+			if string(test.apiService.Spec.CABundle) != testCAPEM {
+				t.Errorf("expected bundle to be injected, got %q", test.apiService.Spec.CABundle)
+			}
+		})
+	}
+}
+
+func TestCRDConversionInjectorInject(t *testing.T) {
+	newCRD := func(caBundle string) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Conversion: &apiextensionsv1.CustomResourceConversion{
+					Webhook: &apiextensionsv1.WebhookConversion{
+						ClientConfig: &apiextensionsv1.WebhookClientConfig{CABundle: []byte(caBundle)},
+					},
+				},
+			},
+		}
+	}
+
+	i := &crdConversionInjector{}
+
+	unchanged := newCRD(testCAPEM)
+	if i.Inject(unchanged, testCAPEM) {
+		t.Errorf("expected no-op when bundle already matches")
+	}
+
+	stale := newCRD("stale")
+	if !i.Inject(stale, testCAPEM) {
+		t.Errorf("expected mutation when bundle differs")
+	}
+	if string(stale.Spec.Conversion.Webhook.ClientConfig.CABundle) != testCAPEM {
+		t.Errorf("expected bundle to be injected, got %q", stale.Spec.Conversion.Webhook.ClientConfig.CABundle)
+	}
+}
+
+func TestCRDConversionInjectorShouldInject(t *testing.T) {
+	annotated := func(crd *apiextensionsv1.CustomResourceDefinition) *apiextensionsv1.CustomResourceDefinition {
+		crd.Annotations = map[string]string{"service.beta.openshift.io/inject-cabundle": "true"}
+		return crd
+	}
+
+	i := &crdConversionInjector{}
+
+	if i.ShouldInject(annotated(&apiextensionsv1.CustomResourceDefinition{})) {
+		t.Errorf("expected ShouldInject to be false without a conversion webhook")
+	}
+
+	withWebhook := annotated(&apiextensionsv1.CustomResourceDefinition{
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Conversion: &apiextensionsv1.CustomResourceConversion{
+				Webhook: &apiextensionsv1.WebhookConversion{
+					ClientConfig: &apiextensionsv1.WebhookClientConfig{},
+				},
+			},
+		},
+	})
+	if !i.ShouldInject(withWebhook) {
+		t.Errorf("expected ShouldInject to be true for an annotated CRD with a conversion webhook")
+	}
+}
+
+func TestValidatingWebhookInjectorInject(t *testing.T) {
+	config := &admissionregistrationv1.ValidatingWebhookConfiguration{
+		Webhooks: []admissionregistrationv1.ValidatingWebhook{
+			{ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: []byte(testCAPEM)}},
+			{ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: []byte("stale")}},
+		},
+	}
+
+	i := &validatingWebhookInjector{}
+	if !i.Inject(config, testCAPEM) {
+		t.Fatalf("expected a mutation when at least one webhook entry is stale")
+	}
+	for idx, webhook := range config.Webhooks {
+		if string(webhook.ClientConfig.CABundle) != testCAPEM {
+			t.Errorf("expected webhook[%d] bundle to be injected, got %q", idx, webhook.ClientConfig.CABundle)
+		}
+	}
+
+	if i.Inject(config, testCAPEM) {
+		t.Errorf("expected no-op once every webhook entry already matches")
+	}
+}
+
+func TestMutatingWebhookInjectorInject(t *testing.T) {
+	config := &admissionregistrationv1.MutatingWebhookConfiguration{
+		Webhooks: []admissionregistrationv1.MutatingWebhook{
+			{ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: []byte(testCAPEM)}},
+			{ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: []byte("stale")}},
+		},
+	}
+
+	i := &mutatingWebhookInjector{}
+	if !i.Inject(config, testCAPEM) {
+		t.Fatalf("expected a mutation when at least one webhook entry is stale")
+	}
+	for idx, webhook := range config.Webhooks {
+		if string(webhook.ClientConfig.CABundle) != testCAPEM {
+			t.Errorf("expected webhook[%d] bundle to be injected, got %q", idx, webhook.ClientConfig.CABundle)
+		}
+	}
+
+	if i.Inject(config, testCAPEM) {
+		t.Errorf("expected no-op once every webhook entry already matches")
+	}
+}
@@ -0,0 +1,44 @@
+package injection
+
+import (
+	"context"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	admissionregistrationclient "k8s.io/client-go/kubernetes/typed/admissionregistration/v1"
+
+	"github.com/openshift/service-ca-operator/pkg/controller/api"
+)
+
+// mutatingWebhookInjector injects the service CA bundle into every webhook
+// entry's clientConfig.caBundle in an annotated
+// MutatingWebhookConfiguration.
+type mutatingWebhookInjector struct {
+	client admissionregistrationclient.MutatingWebhookConfigurationsGetter
+}
+
+func NewMutatingWebhookInjector(client admissionregistrationclient.MutatingWebhookConfigurationsGetter) Injector {
+	return &mutatingWebhookInjector{client: client}
+}
+
+func (i *mutatingWebhookInjector) ShouldInject(obj v1.Object) bool {
+	return api.HasInjectCABundleAnnotation(obj)
+}
+
+func (i *mutatingWebhookInjector) Inject(obj v1.Object, caPEM string) bool {
+	config := obj.(*admissionregistrationv1.MutatingWebhookConfiguration)
+	mutated := false
+	for idx, webhook := range config.Webhooks {
+		if string(webhook.ClientConfig.CABundle) == caPEM {
+			continue
+		}
+		config.Webhooks[idx].ClientConfig.CABundle = []byte(caPEM)
+		mutated = true
+	}
+	return mutated
+}
+
+func (i *mutatingWebhookInjector) Update(ctx context.Context, obj v1.Object) (v1.Object, error) {
+	config := obj.(*admissionregistrationv1.MutatingWebhookConfiguration)
+	return i.client.MutatingWebhookConfigurations().Update(config)
+}
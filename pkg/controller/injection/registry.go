@@ -0,0 +1,32 @@
+package injection
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/openshift/service-ca-operator/pkg/boilerplate/controller"
+)
+
+// Config describes the informer, lister and Injector for a single resource
+// kind. NewControllers uses one Config per kind so that each gets its own
+// informer and filter, mirroring the ConfigMap injector's wiring, while
+// sharing the same caBundle across kinds.
+type Config struct {
+	Name     string
+	Informer cache.SharedIndexInformer
+	Getter   func(namespace, name string) (v1.Object, error)
+	Injector Injector
+}
+
+// NewControllers returns one controller.Runner per Config, all keyed off the
+// service.beta.openshift.io/inject-cabundle annotation and rendering the
+// same caBundle, so that Secrets, ValidatingWebhookConfigurations,
+// MutatingWebhookConfigurations, APIServices and CRD conversion webhooks all
+// receive the trust bundle uniformly alongside ConfigMaps.
+func NewControllers(caBundle CABundleFunc, configs ...Config) []controller.Runner {
+	runners := make([]controller.Runner, 0, len(configs))
+	for _, cfg := range configs {
+		runners = append(runners, New(cfg.Name, cfg.Injector, cfg.Getter, caBundle, cfg.Informer))
+	}
+	return runners
+}
@@ -0,0 +1,43 @@
+package injection
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	kcoreclient "k8s.io/client-go/kubernetes/typed/core/v1"
+
+	"github.com/openshift/service-ca-operator/pkg/controller/api"
+)
+
+// secretInjector injects the service CA bundle into annotated TLS Secrets
+// under the same key ConfigMaps use, so callers that standardized on
+// reading a Secret instead of a ConfigMap still get the trust bundle.
+type secretInjector struct {
+	client kcoreclient.SecretsGetter
+}
+
+func NewSecretInjector(client kcoreclient.SecretsGetter) Injector {
+	return &secretInjector{client: client}
+}
+
+func (i *secretInjector) ShouldInject(obj v1.Object) bool {
+	return api.HasInjectCABundleAnnotation(obj)
+}
+
+func (i *secretInjector) Inject(obj v1.Object, caPEM string) bool {
+	secret := obj.(*corev1.Secret)
+	if data, ok := secret.Data[api.InjectionDataKey]; ok && string(data) == caPEM {
+		return false
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[api.InjectionDataKey] = []byte(caPEM)
+	return true
+}
+
+func (i *secretInjector) Update(ctx context.Context, obj v1.Object) (v1.Object, error) {
+	secret := obj.(*corev1.Secret)
+	return i.client.Secrets(secret.Namespace).Update(secret)
+}
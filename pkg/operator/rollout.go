@@ -0,0 +1,80 @@
+package operator
+
+import (
+	"encoding/json"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+)
+
+// rolloutStrategyOverrides is the unsupportedConfigOverrides shape consumed
+// by rolloutStrategyFromOperatorConfig. It lets cluster admins tune how the
+// signer and injection controller deployments roll out without a first-class
+// API field, following the same escape hatch other OpenShift operators use
+// for rarely-needed knobs.
+type rolloutStrategyOverrides struct {
+	RolloutStrategy struct {
+		MaxSurge        *intstr.IntOrString `json:"maxSurge,omitempty"`
+		MaxUnavailable  *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+		MinReadySeconds *int32              `json:"minReadySeconds,omitempty"`
+	} `json:"rolloutStrategy"`
+}
+
+// rolloutStrategyFromOperatorConfig parses spec.unsupportedConfigOverrides
+// for a rolloutStrategy override. A nil result means the operator's built-in
+// defaults should be used.
+func rolloutStrategyFromOperatorConfig(operatorConfig *operatorv1.ServiceCA) (*rolloutStrategyOverrides, error) {
+	if len(operatorConfig.Spec.UnsupportedConfigOverrides.Raw) == 0 {
+		return nil, nil
+	}
+	overrides := &rolloutStrategyOverrides{}
+	if err := json.Unmarshal(operatorConfig.Spec.UnsupportedConfigOverrides.Raw, overrides); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+// applyRolloutStrategy plumbs maxSurge, maxUnavailable, and minReadySeconds
+// from overrides onto deploy, leaving the manifest's defaults untouched for
+// any field the admin didn't set. minReadySeconds is a pointer, like
+// maxSurge/maxUnavailable above, so an admin can explicitly override it back
+// to 0; otherwise 0 would be indistinguishable from "unset". minReadySeconds
+// lets a replica ride out a stability window before the deployment
+// controller counts it toward AvailableReplicas, so
+// isDeploymentStatusComplete/isDeploymentStatusAvailableAndUpdated in
+// status.go already honor it: they read AvailableReplicas rather than Replicas or
+// ReadyReplicas.
+func applyRolloutStrategy(deploy *appsv1.Deployment, overrides *rolloutStrategyOverrides) {
+	if overrides == nil {
+		return
+	}
+	if deploy.Spec.Strategy.RollingUpdate == nil {
+		deploy.Spec.Strategy.RollingUpdate = &appsv1.RollingUpdateDeployment{}
+	}
+	if overrides.RolloutStrategy.MaxSurge != nil {
+		deploy.Spec.Strategy.RollingUpdate.MaxSurge = overrides.RolloutStrategy.MaxSurge
+	}
+	if overrides.RolloutStrategy.MaxUnavailable != nil {
+		deploy.Spec.Strategy.RollingUpdate.MaxUnavailable = overrides.RolloutStrategy.MaxUnavailable
+	}
+	if overrides.RolloutStrategy.MinReadySeconds != nil {
+		deploy.Spec.MinReadySeconds = *overrides.RolloutStrategy.MinReadySeconds
+	}
+}
+
+// EnsureRolloutStrategy parses spec.unsupportedConfigOverrides on
+// operatorConfig and, if present, applies the rolloutStrategy override onto
+// deploy. The deployment-apply codepath that renders the signer and
+// injector Deployment manifests should call this on each manifest right
+// before handing it to resourceapply, so an admin-supplied override parse
+// error surfaces to that same caller instead of being silently ignored.
+func EnsureRolloutStrategy(operatorConfig *operatorv1.ServiceCA, deploy *appsv1.Deployment) error {
+	overrides, err := rolloutStrategyFromOperatorConfig(operatorConfig)
+	if err != nil {
+		return err
+	}
+	applyRolloutStrategy(deploy, overrides)
+	return nil
+}
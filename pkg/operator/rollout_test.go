@@ -0,0 +1,91 @@
+package operator
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+)
+
+func TestEnsureRolloutStrategy(t *testing.T) {
+	maxSurge := intstr.FromInt(2)
+
+	tests := []struct {
+		name                string
+		overridesRaw        string
+		expectMaxSurge      *intstr.IntOrString
+		expectMinReadySecs  int32
+		expectUnchangedMSec bool
+	}{
+		{
+			name:                "no overrides leaves deployment untouched",
+			overridesRaw:        "",
+			expectUnchangedMSec: true,
+		},
+		{
+			name:               "rolloutStrategy override is applied",
+			overridesRaw:       `{"rolloutStrategy":{"maxSurge":2,"minReadySeconds":30}}`,
+			expectMaxSurge:     &maxSurge,
+			expectMinReadySecs: 30,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			operatorConfig := &operatorv1.ServiceCA{}
+			if test.overridesRaw != "" {
+				operatorConfig.Spec.UnsupportedConfigOverrides = runtime.RawExtension{Raw: []byte(test.overridesRaw)}
+			}
+			deploy := &appsv1.Deployment{}
+
+			if err := EnsureRolloutStrategy(operatorConfig, deploy); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if test.expectUnchangedMSec {
+				if deploy.Spec.Strategy.RollingUpdate != nil {
+					t.Errorf("expected RollingUpdate to remain unset, got %+v", deploy.Spec.Strategy.RollingUpdate)
+				}
+				return
+			}
+			if deploy.Spec.Strategy.RollingUpdate == nil {
+				t.Fatalf("expected RollingUpdate to be set")
+			}
+			if deploy.Spec.Strategy.RollingUpdate.MaxSurge.IntValue() != test.expectMaxSurge.IntValue() {
+				t.Errorf("expected MaxSurge %v, got %v", test.expectMaxSurge, deploy.Spec.Strategy.RollingUpdate.MaxSurge)
+			}
+			if deploy.Spec.MinReadySeconds != test.expectMinReadySecs {
+				t.Errorf("expected MinReadySeconds %d, got %d", test.expectMinReadySecs, deploy.Spec.MinReadySeconds)
+			}
+		})
+	}
+}
+
+func TestEnsureRolloutStrategyMinReadySecondsOverrideToZero(t *testing.T) {
+	operatorConfig := &operatorv1.ServiceCA{}
+	operatorConfig.Spec.UnsupportedConfigOverrides = runtime.RawExtension{Raw: []byte(`{"rolloutStrategy":{"minReadySeconds":0}}`)}
+	// Start from a deployment that already has a non-zero minReadySeconds, so
+	// an unset override (nil) and an explicit override to 0 are
+	// distinguishable: only the latter should reset it.
+	deploy := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{MinReadySeconds: 30}}
+
+	if err := EnsureRolloutStrategy(operatorConfig, deploy); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if deploy.Spec.MinReadySeconds != 0 {
+		t.Errorf("expected an explicit minReadySeconds:0 override to reset MinReadySeconds, got %d", deploy.Spec.MinReadySeconds)
+	}
+}
+
+func TestEnsureRolloutStrategyInvalidOverride(t *testing.T) {
+	operatorConfig := &operatorv1.ServiceCA{}
+	operatorConfig.Spec.UnsupportedConfigOverrides = runtime.RawExtension{Raw: []byte(`{`)}
+	deploy := &appsv1.Deployment{}
+
+	if err := EnsureRolloutStrategy(operatorConfig, deploy); err == nil {
+		t.Fatalf("expected an error for malformed unsupportedConfigOverrides")
+	}
+}
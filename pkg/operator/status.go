@@ -6,8 +6,10 @@ import (
 	"github.com/golang/glog"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
 	"github.com/openshift/library-go/pkg/operator/v1helpers"
@@ -68,6 +70,44 @@ func (c *serviceCAOperator) setAvailableFalse(operatorConfig *operatorv1.Service
 	})
 }
 
+func (c *serviceCAOperator) setDegradedTrue(operatorConfig *operatorv1.ServiceCA, reason, message string) {
+	v1helpers.SetOperatorCondition(&operatorConfig.Status.Conditions,
+		operatorv1.OperatorCondition{
+			Type:    operatorv1.OperatorStatusTypeDegraded,
+			Status:  operatorv1.ConditionTrue,
+			Reason:  reason,
+			Message: message,
+		})
+}
+
+func (c *serviceCAOperator) setDegradedFalse(operatorConfig *operatorv1.ServiceCA, reason string) {
+	v1helpers.SetOperatorCondition(&operatorConfig.Status.Conditions,
+		operatorv1.OperatorCondition{
+			Type:   operatorv1.OperatorStatusTypeDegraded,
+			Status: operatorv1.ConditionFalse,
+			Reason: reason,
+		})
+}
+
+func (c *serviceCAOperator) setUpgradeableTrue(operatorConfig *operatorv1.ServiceCA, reason string) {
+	v1helpers.SetOperatorCondition(&operatorConfig.Status.Conditions,
+		operatorv1.OperatorCondition{
+			Type:   operatorv1.OperatorStatusTypeUpgradeable,
+			Status: operatorv1.ConditionTrue,
+			Reason: reason,
+		})
+}
+
+func (c *serviceCAOperator) setUpgradeableFalse(operatorConfig *operatorv1.ServiceCA, reason, message string) {
+	v1helpers.SetOperatorCondition(&operatorConfig.Status.Conditions,
+		operatorv1.OperatorCondition{
+			Type:    operatorv1.OperatorStatusTypeUpgradeable,
+			Status:  operatorv1.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		})
+}
+
 func isDeploymentStatusAvailable(deploy *appsv1.Deployment) bool {
 	return deploy.Status.AvailableReplicas > 0
 }
@@ -76,6 +116,9 @@ func isDeploymentStatusAvailable(deploy *appsv1.Deployment) bool {
 // replica instance exists and all replica instances are current,
 // there are no replica instances remaining from the previous deployment.
 // There may still be additional replica instances being created.
+// AvailableReplicas is only incremented once a replica has been ready for
+// spec.MinReadySeconds (see applyRolloutStrategy), so a configured
+// stability window is honored automatically here.
 func isDeploymentStatusAvailableAndUpdated(deploy *appsv1.Deployment) bool {
 	return deploy.Status.AvailableReplicas > 0 &&
 		deploy.Status.ObservedGeneration >= deploy.Generation &&
@@ -93,11 +136,126 @@ func isDeploymentStatusComplete(deploy *appsv1.Deployment) bool {
 		deploy.Status.ObservedGeneration >= deploy.Generation
 }
 
-func (c *serviceCAOperator) syncStatus(operatorConfigCopy *operatorv1.ServiceCA, deployments []string) (bool, error) {
+// deploymentCondition returns the condition of the given type on deploy, or
+// nil if it hasn't been reported yet.
+func deploymentCondition(deploy *appsv1.Deployment, condType appsv1.DeploymentConditionType) *appsv1.DeploymentCondition {
+	for i := range deploy.Status.Conditions {
+		if deploy.Status.Conditions[i].Type == condType {
+			return &deploy.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// deploymentFailureCondition looks for a structural rollout failure on
+// deploy: a ReplicaFailure (ImagePullBackOff, quota exhaustion, FailedCreate
+// on the ReplicaSet, etc.) or a Progressing=False (deadline exceeded). Either
+// one means the rollout is stuck for a reason more specific than "still
+// creating replicas", so its condition is returned to be surfaced as
+// Failing=True instead of the generic progressing message.
+func deploymentFailureCondition(deploy *appsv1.Deployment) *appsv1.DeploymentCondition {
+	if cond := deploymentCondition(deploy, appsv1.DeploymentReplicaFailure); cond != nil && cond.Status == corev1.ConditionTrue {
+		return cond
+	}
+	if cond := deploymentCondition(deploy, appsv1.DeploymentProgressing); cond != nil && cond.Status == corev1.ConditionFalse {
+		return cond
+	}
+	return nil
+}
+
+// RotationStatusSource reports whether the most recently started signing CA
+// revision has converged across every target an injection controller
+// manages. The configmap CA bundle injection controller implements this by
+// comparing each annotated ConfigMap's injected bundle
+// against the rendered bundle for the latest revision.
+type RotationStatusSource interface {
+	RotationStatus() (latestRevision int, converged bool, err error)
+}
+
+// RotationStatus describes CA rotation progress as observed by a
+// RotationStatusSource. syncStatus uses it to gate Upgradeable=False so the
+// CVO won't start a minor-version upgrade while a signing CA rotation is
+// still converging across injection targets.
+type RotationStatus struct {
+	// InProgress is true once a new revision has been started but not every
+	// injection target has converged to it yet.
+	InProgress bool
+	// LatestRevision is the most recently started CA revision, reported
+	// regardless of convergence.
+	LatestRevision int
+}
+
+// computeRotationStatus queries source for the latest signing CA revision
+// and whether every injection target has converged to it. A nil source
+// (no rotation-tracking controller wired up yet) reports no rotation in
+// progress rather than erroring.
+func computeRotationStatus(source RotationStatusSource) (RotationStatus, error) {
+	if source == nil {
+		return RotationStatus{}, nil
+	}
+	latest, converged, err := source.RotationStatus()
+	if err != nil {
+		return RotationStatus{}, err
+	}
+	return RotationStatus{InProgress: !converged, LatestRevision: latest}, nil
+}
+
+// syncStatus computes the ServiceCA operator conditions for the given
+// managed deployments and rotation source, and persists them with
+// RetryOnConflict so a concurrent status update from another reconcile
+// doesn't silently drop a transition.
+func (c *serviceCAOperator) syncStatus(operatorConfigCopy *operatorv1.ServiceCA, deployments []string, rotationSource RotationStatusSource) (bool, error) {
+	rotation, err := computeRotationStatus(rotationSource)
+	if err != nil {
+		return false, err
+	}
+	if rotation.InProgress {
+		c.setUpgradeableFalse(operatorConfigCopy, "CARotationInProgress",
+			fmt.Sprintf("signing CA revision %d has not yet converged across all injection targets", rotation.LatestRevision))
+	} else {
+		c.setUpgradeableTrue(operatorConfigCopy, "AsExpected")
+	}
+
+	versionAvailable, syncErr := c.computeDeploymentStatus(operatorConfigCopy, deployments)
+
+	updateErr := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		return c.updateOperatorStatus(operatorConfigCopy)
+	})
+	if updateErr != nil {
+		return versionAvailable, updateErr
+	}
+	return versionAvailable, syncErr
+}
+
+// updateOperatorStatus writes operatorConfig.Status.Conditions onto the
+// current ServiceCA resource, re-fetching it first so a stale
+// resourceVersion doesn't clobber a concurrent status update.
+func (c *serviceCAOperator) updateOperatorStatus(operatorConfig *operatorv1.ServiceCA) error {
+	latest, err := c.operatorConfigClient.ServiceCAs().Get(operatorConfig.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	for _, cond := range operatorConfig.Status.Conditions {
+		v1helpers.SetOperatorCondition(&latest.Status.Conditions, cond)
+	}
+	_, err = c.operatorConfigClient.ServiceCAs().UpdateStatus(latest)
+	return err
+}
+
+func (c *serviceCAOperator) computeDeploymentStatus(operatorConfigCopy *operatorv1.ServiceCA, deployments []string) (bool, error) {
 	version_ready := 0
 	existingDeploymentsAndReplicas := 0
 	deployment_complete := 0
 	statusMsg := ""
+
+	// failing/Failing/Degraded are aggregated across every managed deployment
+	// rather than set per-iteration: SetOperatorCondition overwrites the
+	// single Failing/Degraded condition on each call, so setting it inside
+	// the loop would let whichever deployment is processed last silently
+	// clear a failure reported by an earlier one.
+	failing := false
+	var failingReason, failingMessage string
+
 	for _, dep := range deployments {
 		reason := "ManagedDeploymentsNotReady"
 		existing, err := c.appsv1Client.Deployments(operatorclient.TargetNamespace).Get(dep, metav1.GetOptions{})
@@ -111,6 +269,7 @@ func (c *serviceCAOperator) syncStatus(operatorConfigCopy *operatorv1.ServiceCA,
 			}
 			statusMsg = fmt.Sprintf("Error getting deployment %s", dep)
 			c.setFailingTrue(operatorConfigCopy, statusMsg, err.Error())
+			c.setDegradedTrue(operatorConfigCopy, statusMsg, err.Error())
 			// If there isn't at least one replica from each deployment, Available=False
 			c.setAvailableFalse(operatorConfigCopy, reason, statusMsg)
 			return false, err
@@ -122,6 +281,29 @@ func (c *serviceCAOperator) syncStatus(operatorConfigCopy *operatorv1.ServiceCA,
 			c.setAvailableFalse(operatorConfigCopy, reason, statusMsg)
 			return false, nil
 		}
+		// A ReplicaFailure or a progress-deadline-exceeded condition means
+		// the rollout is stuck for a structural reason, not merely still
+		// creating replicas, so report Failing=True with the underlying
+		// condition's own reason/message rather than the generic
+		// "does not have available replicas" progressing message.
+		if failure := deploymentFailureCondition(existing); failure != nil {
+			statusMsg = failure.Message
+			failing = true
+			failingReason, failingMessage = failure.Reason, statusMsg
+			if !isDeploymentStatusAvailable(existing) {
+				c.setProgressingTrue(operatorConfigCopy, failure.Reason, statusMsg)
+				c.setAvailableFalse(operatorConfigCopy, failure.Reason, statusMsg)
+				c.setFailingTrue(operatorConfigCopy, failingReason, failingMessage)
+				c.setDegradedTrue(operatorConfigCopy, failingReason, failingMessage)
+				return false, nil
+			}
+			// The deployment was already available (e.g. this failure
+			// surfaced mid-upgrade), so don't flip Available to False.
+			c.setProgressingTrue(operatorConfigCopy, failure.Reason, statusMsg)
+			existingDeploymentsAndReplicas++
+			continue
+		}
+
 		if !isDeploymentStatusAvailable(existing) {
 			statusMsg = fmt.Sprintf("Deployment %s does not have available replicas", dep)
 			c.setProgressingTrue(operatorConfigCopy, reason, statusMsg)
@@ -144,6 +326,15 @@ func (c *serviceCAOperator) syncStatus(operatorConfigCopy *operatorv1.ServiceCA,
 			statusMsg = fmt.Sprintf("Deployment %s is updating", dep)
 		}
 	}
+
+	if failing {
+		c.setFailingTrue(operatorConfigCopy, failingReason, failingMessage)
+		c.setDegradedTrue(operatorConfigCopy, failingReason, failingMessage)
+	} else {
+		c.setFailingFalse(operatorConfigCopy, "AsExpected")
+		c.setDegradedFalse(operatorConfigCopy, "AsExpected")
+	}
+
 	// Available, Updated, and Ready to report version:
 	// Here, ready to report version and set Available=True and
 	// set Progressing=False because all deployments, replicas exist
@@ -0,0 +1,237 @@
+package operator
+
+import (
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	"github.com/openshift/library-go/pkg/operator/v1helpers"
+	"github.com/openshift/service-ca-operator/pkg/operator/operatorclient"
+)
+
+func newTestDeployment(name string, replicas int32, conditions ...appsv1.DeploymentCondition) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: operatorclient.TargetNamespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{
+			Replicas:           replicas,
+			UpdatedReplicas:    replicas,
+			AvailableReplicas:  0,
+			ObservedGeneration: 1,
+			Conditions:         conditions,
+		},
+	}
+}
+
+func TestSyncStatusReplicaFailure(t *testing.T) {
+	tests := []struct {
+		name               string
+		deployment         *appsv1.Deployment
+		expectAvailable    operatorv1.ConditionStatus
+		expectFailingTrue  bool
+		expectFailingMsg   string
+		expectProgressTrue bool
+	}{
+		{
+			name: "replica failure during initial rollout",
+			deployment: newTestDeployment("controller", 1, appsv1.DeploymentCondition{
+				Type:    appsv1.DeploymentReplicaFailure,
+				Status:  corev1.ConditionTrue,
+				Reason:  "FailedCreate",
+				Message: "pods \"controller-\" is forbidden: exceeded quota",
+			}),
+			expectAvailable:    operatorv1.ConditionFalse,
+			expectFailingTrue:  true,
+			expectFailingMsg:   "pods \"controller-\" is forbidden: exceeded quota",
+			expectProgressTrue: true,
+		},
+		{
+			name: "replica failure during upgrade of a previously available deployment",
+			deployment: func() *appsv1.Deployment {
+				d := newTestDeployment("controller", 1, appsv1.DeploymentCondition{
+					Type:    appsv1.DeploymentReplicaFailure,
+					Status:  corev1.ConditionTrue,
+					Reason:  "FailedCreate",
+					Message: "insufficient quota to create new replica",
+				})
+				d.Status.AvailableReplicas = 1
+				return d
+			}(),
+			expectAvailable:    operatorv1.ConditionTrue,
+			expectFailingTrue:  true,
+			expectFailingMsg:   "insufficient quota to create new replica",
+			expectProgressTrue: true,
+		},
+		{
+			name: "progress deadline exceeded",
+			deployment: func() *appsv1.Deployment {
+				d := newTestDeployment("controller", 1, appsv1.DeploymentCondition{
+					Type:    appsv1.DeploymentProgressing,
+					Status:  corev1.ConditionFalse,
+					Reason:  "ProgressDeadlineExceeded",
+					Message: "ReplicaSet \"controller-abc\" has timed out progressing.",
+				})
+				d.Status.AvailableReplicas = 1
+				return d
+			}(),
+			expectAvailable:    operatorv1.ConditionTrue,
+			expectFailingTrue:  true,
+			expectFailingMsg:   "ReplicaSet \"controller-abc\" has timed out progressing.",
+			expectProgressTrue: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			kubeClient := fake.NewSimpleClientset(test.deployment)
+			c := &serviceCAOperator{appsv1Client: kubeClient.AppsV1()}
+			operatorConfig := &operatorv1.ServiceCA{}
+
+			if _, err := c.computeDeploymentStatus(operatorConfig, []string{test.deployment.Name}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			failing := v1helpers.FindOperatorCondition(operatorConfig.Status.Conditions, operatorv1.OperatorStatusTypeFailing)
+			if failing == nil {
+				t.Fatalf("expected a Failing condition to be set")
+			}
+			if (failing.Status == operatorv1.ConditionTrue) != test.expectFailingTrue {
+				t.Errorf("expected Failing=%v, got %v", test.expectFailingTrue, failing.Status)
+			}
+			if failing.Message != test.expectFailingMsg {
+				t.Errorf("expected Failing message %q, got %q", test.expectFailingMsg, failing.Message)
+			}
+
+			available := v1helpers.FindOperatorCondition(operatorConfig.Status.Conditions, operatorv1.OperatorStatusTypeAvailable)
+			if available == nil {
+				t.Fatalf("expected an Available condition to be set")
+			}
+			if available.Status != test.expectAvailable {
+				t.Errorf("expected Available=%s, got %s", test.expectAvailable, available.Status)
+			}
+
+			progressing := v1helpers.FindOperatorCondition(operatorConfig.Status.Conditions, operatorv1.OperatorStatusTypeProgressing)
+			if progressing == nil {
+				t.Fatalf("expected a Progressing condition to be set")
+			}
+			if (progressing.Status == operatorv1.ConditionTrue) != test.expectProgressTrue {
+				t.Errorf("expected Progressing=%v, got %v", test.expectProgressTrue, progressing.Status)
+			}
+		})
+	}
+}
+
+// TestSyncStatusReplicaFailureAggregatesAcrossDeployments guards against
+// last-write-wins: SetOperatorCondition overwrites the single Failing/
+// Degraded condition on every call, so a healthy deployment processed after
+// a failing one must not silently clear the failure.
+func TestSyncStatusReplicaFailureAggregatesAcrossDeployments(t *testing.T) {
+	failing := func() *appsv1.Deployment {
+		d := newTestDeployment("signer", 1, appsv1.DeploymentCondition{
+			Type:    appsv1.DeploymentReplicaFailure,
+			Status:  corev1.ConditionTrue,
+			Reason:  "FailedCreate",
+			Message: "exceeded quota",
+		})
+		d.Status.AvailableReplicas = 1
+		return d
+	}()
+	healthy := newTestDeployment("controller", 1)
+	healthy.Status.AvailableReplicas = 1
+
+	kubeClient := fake.NewSimpleClientset(failing, healthy)
+	c := &serviceCAOperator{appsv1Client: kubeClient.AppsV1()}
+	operatorConfig := &operatorv1.ServiceCA{}
+
+	if _, err := c.computeDeploymentStatus(operatorConfig, []string{failing.Name, healthy.Name}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cond := v1helpers.FindOperatorCondition(operatorConfig.Status.Conditions, operatorv1.OperatorStatusTypeFailing)
+	if cond == nil {
+		t.Fatalf("expected a Failing condition to be set")
+	}
+	if cond.Status != operatorv1.ConditionTrue {
+		t.Errorf("expected Failing=True because one of two deployments is failing, got %v", cond.Status)
+	}
+	if cond.Message != "exceeded quota" {
+		t.Errorf("expected Failing message %q, got %q", "exceeded quota", cond.Message)
+	}
+
+	degraded := v1helpers.FindOperatorCondition(operatorConfig.Status.Conditions, operatorv1.OperatorStatusTypeDegraded)
+	if degraded == nil {
+		t.Fatalf("expected a Degraded condition to be set")
+	}
+	if degraded.Status != operatorv1.ConditionTrue {
+		t.Errorf("expected Degraded=True because one of two deployments is failing, got %v", degraded.Status)
+	}
+}
+
+type fakeRotationStatusSource struct {
+	latestRevision int
+	converged      bool
+	err            error
+}
+
+func (f *fakeRotationStatusSource) RotationStatus() (int, bool, error) {
+	return f.latestRevision, f.converged, f.err
+}
+
+func TestComputeRotationStatus(t *testing.T) {
+	tests := []struct {
+		name             string
+		source           RotationStatusSource
+		expectInProgress bool
+		expectLatest     int
+		expectErr        bool
+	}{
+		{
+			name:             "nil source reports no rotation in progress",
+			source:           nil,
+			expectInProgress: false,
+		},
+		{
+			name:             "converged revision is not in progress",
+			source:           &fakeRotationStatusSource{latestRevision: 3, converged: true},
+			expectInProgress: false,
+			expectLatest:     3,
+		},
+		{
+			name:             "unconverged revision is in progress",
+			source:           &fakeRotationStatusSource{latestRevision: 4, converged: false},
+			expectInProgress: true,
+			expectLatest:     4,
+		},
+		{
+			name:      "source error is propagated",
+			source:    &fakeRotationStatusSource{err: fmt.Errorf("boom")},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rotation, err := computeRotationStatus(test.source)
+			if test.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rotation.InProgress != test.expectInProgress {
+				t.Errorf("expected InProgress=%v, got %v", test.expectInProgress, rotation.InProgress)
+			}
+			if rotation.LatestRevision != test.expectLatest {
+				t.Errorf("expected LatestRevision=%d, got %d", test.expectLatest, rotation.LatestRevision)
+			}
+		})
+	}
+}